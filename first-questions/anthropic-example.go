@@ -1,24 +1,72 @@
-package main
+package llm
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 const baseUrl string = "https://api.anthropic.com/v1/messages"
 
+// anthropicClient is a ModelProvider for Anthropic's Messages API, reusing
+// the same Credential/CredentialSource renewal machinery as OpenAIProvider
+// instead of its own credential-watching logic.
 type anthropicClient struct {
-	apiKey string
-	client *http.Client
+	credential atomic.Pointer[Credential]
+	client     *http.Client
 }
 
 func newAnthropicClient(apiKey string) *anthropicClient {
-	return &anthropicClient{
-		apiKey: apiKey,
+	c := &anthropicClient{
 		client: &http.Client{},
 	}
+	c.credential.Store(&Credential{Value: apiKey})
+	return c
+}
+
+// UpdateCredential atomically swaps the API key used for subsequent requests.
+func (c *anthropicClient) UpdateCredential(cred Credential) {
+	c.credential.Store(&cred)
+}
+
+func (c *anthropicClient) IsAvailable() bool {
+	cred := c.credential.Load()
+	if cred.TTL > 0 && time.Now().After(cred.ExpiresAt()) {
+		return false
+	}
+	return true
+}
+
+func (c *anthropicClient) GenerateText(ctx context.Context, req ModelRequest) (ModelResponse, error) {
+	text, err := c.generate2(req.Prompt)
+	if err != nil {
+		return ModelResponse{}, err
+	}
+	return ModelResponse{Text: text, Provider: Anthropic, ModelName: req.ModelName}, nil
+}
+
+func (c *anthropicClient) StreamText(ctx context.Context, req ModelRequest) (<-chan StreamChunk, <-chan error) {
+	// Native Anthropic SSE streaming would go here; for now the full response
+	// is emitted as a single terminal chunk.
+	chunks := make(chan StreamChunk, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		text, err := c.generate2(req.Prompt)
+		if err != nil {
+			errs <- err
+			return
+		}
+		chunks <- StreamChunk{Text: text, Done: true, Model: req.ModelName}
+	}()
 
+	return chunks, errs
 }
 
 func (c *anthropicClient) generate2(prompt string) (string, error) {
@@ -42,7 +90,7 @@ func (c *anthropicClient) generate2(prompt string) (string, error) {
 
 	req, _ := http.NewRequest("POST", baseUrl, bytes.NewBuffer(body))
 
-	req.Header.Add("x-api-key", c.apiKey)
+	req.Header.Add("x-api-key", c.credential.Load().Value)
 	req.Header.Add("anthropic-version", "2023-06-01")
 	req.Header.Add("Content-Type", "application/json")
 
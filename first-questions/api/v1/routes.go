@@ -0,0 +1,246 @@
+// Package v1 exposes LLMProxy and ModelRouter internals over HTTP for
+// runtime introspection, modeled after a Prometheus/Thanos-style v1 API.
+package v1
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	llm "github.com/GLStephen/speaking/first-questions"
+)
+
+// gzipThreshold is the response body size, in bytes, above which Register's
+// handlers gzip-compress the body when the client advertises support for it.
+const gzipThreshold = 1024
+
+// envelope is the consistent response shape for every endpoint in this API.
+type envelope struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// API wires an LLMProxy and ModelRouter up to the v1 HTTP routes.
+type API struct {
+	proxy  *llm.LLMProxy
+	router *llm.ModelRouter
+}
+
+// NewAPI builds an API for the given proxy and router.
+func NewAPI(proxy *llm.LLMProxy, router *llm.ModelRouter) *API {
+	return &API{proxy: proxy, router: router}
+}
+
+// Register attaches every v1 route to mux.
+func (a *API) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v1/metrics", a.handleMetrics)
+	mux.HandleFunc("/api/v1/providers", a.handleProviders)
+	mux.HandleFunc("/api/v1/providers/", a.handleProviderReset)
+	mux.HandleFunc("/api/v1/cache/stats", a.handleCacheStats)
+	mux.HandleFunc("/api/v1/cache", a.handleCacheFlush)
+	mux.HandleFunc("/api/v1/requests", a.handleRecentRequests)
+}
+
+func (a *API) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	snapshot := a.proxy.Metrics()
+
+	if strings.Contains(r.Header.Get("Accept"), "text/plain") {
+		a.writePrometheusMetrics(w, r, snapshot)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, envelope{
+		Status: "success",
+		Data: map[string]interface{}{
+			"total_requests":      snapshot.TotalRequests,
+			"cache_hits":          snapshot.CacheHits,
+			"cache_hit_ratio":     snapshot.CacheHitRatio(),
+			"tokens_used":         snapshot.TokensUsed,
+			"estimated_cost":      snapshot.EstimatedCost,
+			"average_latency_ms":  snapshot.AverageLatency().Milliseconds(),
+			"rate_limit_allowed":  snapshot.RateLimitAllowed,
+			"rate_limit_rejected": snapshot.RateLimitRejected,
+		},
+	})
+}
+
+// writePrometheusMetrics renders snapshot as a Prometheus text exposition.
+func (a *API) writePrometheusMetrics(w http.ResponseWriter, r *http.Request, snapshot llm.MetricsSnapshot) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "# TYPE llm_proxy_requests_total counter\nllm_proxy_requests_total %d\n", snapshot.TotalRequests)
+	fmt.Fprintf(&body, "# TYPE llm_proxy_cache_hits_total counter\nllm_proxy_cache_hits_total %d\n", snapshot.CacheHits)
+	fmt.Fprintf(&body, "# TYPE llm_proxy_tokens_used_total counter\nllm_proxy_tokens_used_total %d\n", snapshot.TokensUsed)
+	fmt.Fprintf(&body, "# TYPE llm_proxy_estimated_cost_total counter\nllm_proxy_estimated_cost_total %f\n", snapshot.EstimatedCost)
+	fmt.Fprintf(&body, "# TYPE llm_proxy_rate_limit_allowed_total counter\nllm_proxy_rate_limit_allowed_total %d\n", snapshot.RateLimitAllowed)
+	fmt.Fprintf(&body, "# TYPE llm_proxy_rate_limit_rejected_total counter\nllm_proxy_rate_limit_rejected_total %d\n", snapshot.RateLimitRejected)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeBody(w, r, http.StatusOK, []byte(body.String()))
+}
+
+func (a *API) handleProviders(w http.ResponseWriter, r *http.Request) {
+	health := a.router.HealthSnapshot()
+
+	type modelHealth struct {
+		Model               string `json:"model"`
+		State               string `json:"state"`
+		ConsecutiveFailures int    `json:"consecutive_failures"`
+		LastError           string `json:"last_error,omitempty"`
+	}
+	type providerView struct {
+		Name      string        `json:"name"`
+		Available bool          `json:"available"`
+		Models    []modelHealth `json:"models"`
+	}
+
+	byProvider := make(map[llm.Provider][]modelHealth)
+	for key, h := range health {
+		lastErr := ""
+		if h.LastError != nil {
+			lastErr = h.LastError.Error()
+		}
+		byProvider[key.Provider] = append(byProvider[key.Provider], modelHealth{
+			Model:               key.ModelName,
+			State:               h.State.String(),
+			ConsecutiveFailures: h.ConsecutiveFailures,
+			LastError:           lastErr,
+		})
+	}
+
+	views := make([]providerView, 0)
+	for _, info := range a.router.Providers() {
+		views = append(views, providerView{
+			Name:      string(info.Provider),
+			Available: info.Available,
+			Models:    byProvider[info.Provider],
+		})
+	}
+
+	respondJSON(w, r, http.StatusOK, envelope{Status: "success", Data: views})
+}
+
+// handleProviderReset serves POST /api/v1/providers/{name}/reset, forcing
+// every circuit breaker registered for that provider back to Closed.
+func (a *API) handleProviderReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", fmt.Errorf("%s not allowed", r.Method))
+		return
+	}
+
+	if !strings.HasSuffix(r.URL.Path, "/reset") {
+		respondError(w, r, http.StatusNotFound, "not_found", fmt.Errorf("unknown route %q", r.URL.Path))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/providers/")
+	name = strings.TrimSuffix(name, "/reset")
+	if name == "" || name == r.URL.Path {
+		respondError(w, r, http.StatusNotFound, "not_found", fmt.Errorf("unknown route %q", r.URL.Path))
+		return
+	}
+
+	reset := 0
+	for key := range a.router.HealthSnapshot() {
+		if string(key.Provider) != name {
+			continue
+		}
+		if a.router.ResetCircuit(key) {
+			reset++
+		}
+	}
+
+	respondJSON(w, r, http.StatusOK, envelope{Status: "success", Data: map[string]interface{}{
+		"provider": name,
+		"reset":    reset,
+	}})
+}
+
+func (a *API) handleCacheStats(w http.ResponseWriter, r *http.Request) {
+	stats := a.proxy.Cache().Stats()
+	respondJSON(w, r, http.StatusOK, envelope{Status: "success", Data: stats})
+}
+
+// handleCacheFlush serves DELETE /api/v1/cache, flushing all entries or only
+// those whose key starts with the ?prefix= query parameter.
+func (a *API) handleCacheFlush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", fmt.Errorf("%s not allowed", r.Method))
+		return
+	}
+
+	removed := a.proxy.Cache().Flush(r.URL.Query().Get("prefix"))
+	respondJSON(w, r, http.StatusOK, envelope{Status: "success", Data: map[string]interface{}{"removed": removed}})
+}
+
+// handleRecentRequests serves GET /api/v1/requests?limit=N, returning the
+// proxy's ring buffer of recently processed requests, newest first. Prompts
+// are already PII-filtered by the time ProcessRequest records them.
+func (a *API) handleRecentRequests(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	type requestView struct {
+		Request   llm.Request        `json:"request"`
+		Response  *llm.ProxyResponse `json:"response,omitempty"`
+		Error     string             `json:"error,omitempty"`
+		Timestamp string             `json:"timestamp"`
+	}
+
+	recent := a.proxy.RecentRequests(limit)
+	views := make([]requestView, 0, len(recent))
+	for _, entry := range recent {
+		errMsg := ""
+		if entry.Err != nil {
+			errMsg = entry.Err.Error()
+		}
+		views = append(views, requestView{
+			Request:   entry.Request,
+			Response:  entry.Response,
+			Error:     errMsg,
+			Timestamp: entry.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"),
+		})
+	}
+
+	respondJSON(w, r, http.StatusOK, envelope{Status: "success", Data: views})
+}
+
+func respondJSON(w http.ResponseWriter, r *http.Request, status int, body envelope) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeBody(w, r, status, payload)
+}
+
+func respondError(w http.ResponseWriter, r *http.Request, status int, errType string, err error) {
+	respondJSON(w, r, status, envelope{Status: "error", ErrorType: errType, Error: err.Error()})
+}
+
+// writeBody gzip-compresses payload when it's over gzipThreshold and the
+// client advertised gzip support, otherwise writes it as-is. Headers must be
+// set by the caller before this runs, since it calls WriteHeader.
+func writeBody(w http.ResponseWriter, r *http.Request, status int, payload []byte) {
+	if len(payload) <= gzipThreshold || !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.WriteHeader(status)
+		w.Write(payload)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(status)
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	gz.Write(payload)
+}
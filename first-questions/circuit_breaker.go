@@ -0,0 +1,227 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a per-(Provider, ModelName) circuit breaker.
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// ErrorClass buckets provider failures so breakers and dashboards can reason
+// about them uniformly.
+type ErrorClass string
+
+const (
+	ErrorClassNone          ErrorClass = ""
+	ErrorClass5xx           ErrorClass = "5xx"
+	ErrorClass429           ErrorClass = "429"
+	ErrorClassTimeout       ErrorClass = "timeout"
+	ErrorClassInvalidOutput ErrorClass = "invalid_output"
+	ErrorClassUnknown       ErrorClass = "unknown"
+)
+
+// ClassifiableError lets a provider attach a specific ErrorClass to an error
+// it returns; classifyError falls back to a generic guess when a returned
+// error doesn't implement this.
+type ClassifiableError interface {
+	ErrorClass() ErrorClass
+}
+
+func classifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var classifiable ClassifiableError
+	if errors.As(err, &classifiable) {
+		return classifiable.ErrorClass()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+	return ErrorClassUnknown
+}
+
+// ModelKey identifies a specific model offered by a specific provider.
+type ModelKey struct {
+	Provider  Provider
+	ModelName string
+}
+
+// CircuitBreakerConfig tunes when a breaker trips and how long it stays open.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // consecutive failures that trip the breaker
+	FailureRatio     float64       // failure ratio over WindowSize that trips the breaker
+	WindowSize       int           // number of recent outcomes considered for FailureRatio
+	Cooldown         time.Duration // time spent Open before probing HalfOpen
+}
+
+// DefaultCircuitBreakerConfig trips after 5 consecutive failures or a 50%
+// failure ratio over the last 20 outcomes, and stays Open for 30 seconds.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	FailureRatio:     0.5,
+	WindowSize:       20,
+	Cooldown:         30 * time.Second,
+}
+
+// circuitBreaker tracks health for a single ModelKey.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	config           CircuitBreakerConfig
+	state            CircuitState
+	consecutiveFails int
+	outcomes         []bool // ring of recent successes (true) / failures (false)
+	openedAt         time.Time
+	probing          bool
+	lastError        error
+	lastErrorClass   ErrorClass
+	lastLatency      time.Duration
+}
+
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{config: config, state: CircuitClosed}
+}
+
+// Allow reports whether a request against this breaker's model should be
+// attempted right now. Once the cooldown has elapsed, a true result admits
+// the single HalfOpen probe; the caller must then report its outcome via
+// RecordOutcome or give it back via ReleaseProbe.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.config.Cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		// Only one probe in flight at a time; additional callers are
+		// treated as still-open until the probe resolves.
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// ReleaseProbe releases the single in-flight HalfOpen probe admitted by
+// Allow, without recording a success or failure. Callers that find they
+// can't actually attempt the call after Allow returns true (e.g. the
+// provider was not found or reports itself unavailable) must call this so a
+// later caller can take the probe instead of it being stuck forever.
+func (b *circuitBreaker) ReleaseProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// RecordOutcome updates breaker state after a call that Allow admitted.
+func (b *circuitBreaker) RecordOutcome(success bool, latency time.Duration, class ErrorClass, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastLatency = latency
+	b.lastError = err
+	b.lastErrorClass = class
+
+	b.outcomes = append(b.outcomes, success)
+	if len(b.outcomes) > b.config.WindowSize {
+		b.outcomes = b.outcomes[len(b.outcomes)-b.config.WindowSize:]
+	}
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+		if success {
+			b.state = CircuitClosed
+			b.consecutiveFails = 0
+			b.outcomes = b.outcomes[:0]
+		} else {
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+		}
+		return
+	}
+
+	if success {
+		b.consecutiveFails = 0
+		return
+	}
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.config.FailureThreshold || b.failureRatioExceeded() {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *circuitBreaker) failureRatioExceeded() bool {
+	if len(b.outcomes) < b.config.WindowSize {
+		return false
+	}
+	failures := 0
+	for _, ok := range b.outcomes {
+		if !ok {
+			failures++
+		}
+	}
+	return float64(failures)/float64(len(b.outcomes)) >= b.config.FailureRatio
+}
+
+// ProviderHealth is a point-in-time snapshot of one ModelKey's breaker.
+type ProviderHealth struct {
+	State               CircuitState
+	ConsecutiveFailures int
+	LastError           error
+	LastErrorClass      ErrorClass
+	LastLatency         time.Duration
+}
+
+// Reset forces the breaker back to Closed, clearing failure history. Used by
+// an operator-triggered "force circuit closed" action.
+func (b *circuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = CircuitClosed
+	b.consecutiveFails = 0
+	b.probing = false
+	b.outcomes = b.outcomes[:0]
+}
+
+func (b *circuitBreaker) snapshot() ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return ProviderHealth{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFails,
+		LastError:           b.lastError,
+		LastErrorClass:      b.lastErrorClass,
+		LastLatency:         b.lastLatency,
+	}
+}
@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Credential is a renewable secret (API key, OAuth token, STS/Vault lease)
+// handed out by a CredentialSource.
+type Credential struct {
+	Value     string
+	TTL       time.Duration
+	Renewable bool
+	FetchedAt time.Time
+}
+
+// ExpiresAt returns the instant this credential stops being valid.
+func (c Credential) ExpiresAt() time.Time {
+	return c.FetchedAt.Add(c.TTL)
+}
+
+// CredentialSource fetches a fresh Credential on demand.
+type CredentialSource interface {
+	Fetch(ctx context.Context) (Credential, error)
+}
+
+// StaticEnvCredentialSource reads a credential from an environment variable.
+// It never expires, so watchCredential will not attempt to renew it.
+type StaticEnvCredentialSource struct {
+	EnvVar string
+}
+
+func (s StaticEnvCredentialSource) Fetch(ctx context.Context) (Credential, error) {
+	value := os.Getenv(s.EnvVar)
+	if value == "" {
+		return Credential{}, fmt.Errorf("credentials: env var %q is not set", s.EnvVar)
+	}
+	return Credential{Value: value, TTL: 0, Renewable: false, FetchedAt: time.Now()}, nil
+}
+
+// FileCredentialSource reads a credential from disk, re-reading the file on
+// every Fetch so an external process can rotate it in place.
+type FileCredentialSource struct {
+	Path string
+	TTL  time.Duration
+}
+
+func (s FileCredentialSource) Fetch(ctx context.Context) (Credential, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return Credential{}, fmt.Errorf("credentials: reading %q: %w", s.Path, err)
+	}
+	return Credential{Value: string(data), TTL: s.TTL, Renewable: true, FetchedAt: time.Now()}, nil
+}
+
+// HTTPCredentialSource fetches a credential from a token-issuing endpoint
+// (e.g. a short-lived STS/Vault lease) using FetchFunc to do the actual call.
+type HTTPCredentialSource struct {
+	Endpoint  string
+	FetchFunc func(ctx context.Context, endpoint string) (Credential, error)
+}
+
+func (s HTTPCredentialSource) Fetch(ctx context.Context) (Credential, error) {
+	if s.FetchFunc == nil {
+		return Credential{}, fmt.Errorf("credentials: no FetchFunc configured for %q", s.Endpoint)
+	}
+	return s.FetchFunc(ctx, s.Endpoint)
+}
+
+// credentialWatcher holds the renewable state for a single provider's
+// credential, swapped atomically so in-flight requests never observe a
+// half-updated value.
+type credentialWatcher struct {
+	current atomic.Pointer[Credential]
+	source  CredentialSource
+	apply   func(Credential)
+	cancel  context.CancelFunc
+}
+
+// watchCredential fetches an initial credential, applies it, and then-if the
+// credential is renewable-starts a background goroutine that refreshes it at
+// TTL*2/3. Fetch failures are retried with exponential backoff while the
+// previous credential continues to serve requests until it actually expires.
+func watchCredential(ctx context.Context, source CredentialSource, apply func(Credential)) (*credentialWatcher, error) {
+	initial, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apply(initial)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	w := &credentialWatcher{source: source, apply: apply, cancel: cancel}
+	w.current.Store(&initial)
+
+	if initial.Renewable && initial.TTL > 0 {
+		go w.run(watchCtx)
+	}
+
+	return w, nil
+}
+
+func (w *credentialWatcher) run(ctx context.Context) {
+	const maxBackoff = time.Minute
+
+	for {
+		cred := w.current.Load()
+		renewAt := cred.TTL * 2 / 3
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(renewAt):
+		}
+
+		// Ignore-errors: keep serving the previous credential and retry on
+		// the backoff timer until either a Fetch succeeds or the credential
+		// truly expires, rather than falling back to the full renewAt wait
+		// between attempts.
+		backoff := time.Second
+		for {
+			next, err := w.source.Fetch(ctx)
+			if err == nil {
+				w.current.Store(&next)
+				w.apply(next)
+				break
+			}
+
+			if time.Now().After(cred.ExpiresAt()) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
+
+// Stop terminates the background renewal goroutine, if one was started.
+func (w *credentialWatcher) Stop() {
+	w.cancel()
+}
@@ -0,0 +1,70 @@
+package llm
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer is a resettable per-attempt timeout, borrowed from the
+// deadline-timer pattern in gonet: readCancelCh/writeCancelCh are closed when
+// the timer fires, and reset stops the previous timer, replaces both channels
+// and arms a new time.AfterFunc timer, so one deadlineTimer can be reused
+// across retry attempts.
+type deadlineTimer struct {
+	mu            sync.Mutex
+	timer         *time.Timer
+	readCancelCh  chan struct{}
+	writeCancelCh chan struct{}
+}
+
+// newDeadlineTimer returns a deadlineTimer with no timer armed; call reset
+// before waiting on readCancel/writeCancel.
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{
+		readCancelCh:  make(chan struct{}),
+		writeCancelCh: make(chan struct{}),
+	}
+}
+
+// reset stops any previously armed timer and arms a new one for d, closing
+// readCancelCh and writeCancelCh when it fires.
+func (t *deadlineTimer) reset(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+
+	readCh := make(chan struct{})
+	writeCh := make(chan struct{})
+	t.readCancelCh = readCh
+	t.writeCancelCh = writeCh
+	t.timer = time.AfterFunc(d, func() {
+		close(readCh)
+		close(writeCh)
+	})
+}
+
+// stop disarms the timer; safe to call even if reset was never called.
+func (t *deadlineTimer) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// readCancel is closed when the most recent reset's deadline elapses.
+func (t *deadlineTimer) readCancel() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.readCancelCh
+}
+
+// writeCancel is closed when the most recent reset's deadline elapses.
+func (t *deadlineTimer) writeCancel() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.writeCancelCh
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,8 +19,32 @@ type ProxyConfig struct {
 	CostLimit       float64       // maximum cost per day
 	CustomHeaders   http.Header
 	FilterFunction  func(string) string // for PII filtering
+
+	// RateLimitAlgorithm selects the limiting strategy used by checkRateLimits.
+	// Defaults to AlgorithmTokenBucket when unset.
+	RateLimitAlgorithm RateLimitAlgorithm
+
+	// UserRateLimits overrides RateLimit on a per-user basis, keyed by Request.UserID.
+	UserRateLimits map[string]int
+
+	// CacheTTL controls how long a cached response stays valid. Defaults to
+	// defaultCacheTTL when unset.
+	CacheTTL time.Duration
 }
 
+// defaultCacheTTL is used when ProxyConfig.CacheTTL is unset.
+const defaultCacheTTL = 5 * time.Minute
+
+// RateLimitAlgorithm selects which rate limiting strategy a RateLimiter enforces.
+type RateLimitAlgorithm int
+
+const (
+	// AlgorithmTokenBucket refills the full limit at the start of each window.
+	AlgorithmTokenBucket RateLimitAlgorithm = iota
+	// AlgorithmLeakyBucket decays remaining budget continuously based on elapsed time.
+	AlgorithmLeakyBucket
+)
+
 type RetryConfig struct {
 	MaxRetries  int
 	BackoffBase time.Duration
@@ -27,12 +52,14 @@ type RetryConfig struct {
 
 // ProxyMetrics tracks usage and performance metrics
 type ProxyMetrics struct {
-	TotalRequests     int64
-	CacheHits         int64
-	Latency          time.Duration
-	TokensUsed       int64
-	EstimatedCost    float64
-	mu               sync.RWMutex
+	TotalRequests       int64
+	CacheHits           int64
+	Latency             time.Duration
+	TokensUsed          int64
+	EstimatedCost       float64
+	RateLimitAllowed    int64 // llm_proxy_rate_limit_allowed_total
+	RateLimitRejected   int64 // llm_proxy_rate_limit_rejected_total
+	mu                  sync.RWMutex
 }
 
 // LLMProxy provides a proxy layer for LLM requests
@@ -40,6 +67,53 @@ type LLMProxy struct {
 	config  ProxyConfig
 	metrics ProxyMetrics
 	cache   *RequestCache
+	limiter *RateLimiter
+
+	recentMu  sync.Mutex
+	recent    []RecentRequest
+	recentCap int
+}
+
+// RecentRequest is one entry in LLMProxy's ring buffer of recently processed
+// requests, exposed for introspection (e.g. a debugging HTTP API).
+type RecentRequest struct {
+	Request   Request // Prompt reflects FilterFunction's PII-filtered output
+	Response  *ProxyResponse
+	Err       error
+	Timestamp time.Time
+}
+
+// recordRecent appends to the ring buffer, evicting the oldest entry once
+// recentCap is reached.
+func (p *LLMProxy) recordRecent(req Request, resp *ProxyResponse, err error) {
+	if p.recentCap <= 0 {
+		return
+	}
+
+	p.recentMu.Lock()
+	defer p.recentMu.Unlock()
+
+	p.recent = append(p.recent, RecentRequest{Request: req, Response: resp, Err: err, Timestamp: time.Now()})
+	if len(p.recent) > p.recentCap {
+		p.recent = p.recent[len(p.recent)-p.recentCap:]
+	}
+}
+
+// RecentRequests returns up to limit of the most recently processed
+// requests, newest first.
+func (p *LLMProxy) RecentRequests(limit int) []RecentRequest {
+	p.recentMu.Lock()
+	defer p.recentMu.Unlock()
+
+	if limit <= 0 || limit > len(p.recent) {
+		limit = len(p.recent)
+	}
+
+	out := make([]RecentRequest, limit)
+	for i := 0; i < limit; i++ {
+		out[i] = p.recent[len(p.recent)-1-i]
+	}
+	return out
 }
 
 // RequestCache implements a simple cache for LLM requests
@@ -54,13 +128,231 @@ type CacheEntry struct {
 	Cost        float64
 }
 
+// Get returns the cached entry for key, enforcing Expiration: an entry found
+// past its expiration is treated as a miss and evicted.
+func (c *RequestCache) Get(key string) (CacheEntry, bool) {
+	value, ok := c.entries.Load(key)
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	entry := value.(CacheEntry)
+	if !entry.Expiration.IsZero() && time.Now().After(entry.Expiration) {
+		c.entries.Delete(key)
+		return CacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// GetWithContext behaves like Get but checks ctx first, returning ctx.Err()
+// promptly instead of proceeding if the caller's deadline has already
+// passed - notably without waiting behind any in-progress expiry scan over
+// the underlying sync.Map.
+func (c *RequestCache) GetWithContext(ctx context.Context, key string) (CacheEntry, bool, error) {
+	select {
+	case <-ctx.Done():
+		return CacheEntry{}, false, ctx.Err()
+	default:
+	}
+
+	entry, hit := c.Get(key)
+	return entry, hit, nil
+}
+
+// Set stores entry under key.
+func (c *RequestCache) Set(key string, entry CacheEntry) {
+	c.entries.Store(key, entry)
+}
+
+// SetWithContext behaves like Set but honors ctx, skipping the write and
+// returning ctx.Err() if the deadline has already passed.
+func (c *RequestCache) SetWithContext(ctx context.Context, key string, entry CacheEntry) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	c.Set(key, entry)
+	return nil
+}
+
+// defaultRecentCapacity bounds the in-memory ring buffer of recent requests
+// surfaced by RecentRequests.
+const defaultRecentCapacity = 100
+
 // NewLLMProxy creates a new proxy instance
 func NewLLMProxy(config ProxyConfig) *LLMProxy {
 	return &LLMProxy{
-		config: config,
-		cache:  &RequestCache{},
-		metrics: ProxyMetrics{},
+		config:    config,
+		cache:     &RequestCache{},
+		metrics:   ProxyMetrics{},
+		limiter:   newRateLimiter(config),
+		recentCap: defaultRecentCapacity,
+	}
+}
+
+// globalRateLimitKey is used for requests that don't carry a UserID.
+const globalRateLimitKey = "__global__"
+
+// rateLimitKey derives the limiter key for a request, falling back to a
+// shared global bucket when no UserID is present.
+func rateLimitKey(req Request) string {
+	if req.UserID != "" {
+		return req.UserID
+	}
+	return globalRateLimitKey
+}
+
+// RateLimitError is returned when a request is rejected by the rate limiter.
+type RateLimitError struct {
+	Key        string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %q, retry after %s", e.Key, e.RetryAfter)
+}
+
+// bucketState holds the per-key accounting used by both limiter algorithms.
+type bucketState struct {
+	remaining int
+	limit     int
+	resetAt   time.Time // token bucket: start of the next refill window
+	updated   time.Time // leaky bucket: last time remaining was decayed
+}
+
+// RateLimiter enforces per-key request limits using a pluggable algorithm.
+type RateLimiter struct {
+	mu        sync.Mutex
+	algorithm RateLimitAlgorithm
+	window    time.Duration
+	limit     int
+	overrides map[string]int
+	states    map[string]*bucketState
+}
+
+// newRateLimiter builds a RateLimiter from the proxy config. RateLimit is
+// expressed in requests per minute.
+func newRateLimiter(config ProxyConfig) *RateLimiter {
+	return &RateLimiter{
+		algorithm: config.RateLimitAlgorithm,
+		window:    time.Minute,
+		limit:     config.RateLimit,
+		overrides: config.UserRateLimits,
+		states:    make(map[string]*bucketState),
+	}
+}
+
+// limitFor returns the effective limit for a key, honoring per-user overrides.
+func (rl *RateLimiter) limitFor(key string) int {
+	if limit, ok := rl.overrides[key]; ok {
+		return limit
 	}
+	return rl.limit
+}
+
+// Allow checks and consumes one unit of budget for key, returning the time
+// the caller should wait before retrying when the request is rejected.
+func (rl *RateLimiter) Allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	limit := rl.limitFor(key)
+	if limit <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+	state, ok := rl.states[key]
+	if !ok {
+		state = &bucketState{limit: limit, resetAt: now.Add(rl.window), updated: now}
+		if rl.algorithm == AlgorithmLeakyBucket {
+			// Leaky bucket tracks units consumed in the window, not units
+			// left, so a brand-new key starts empty (0 consumed).
+			state.remaining = 0
+		} else {
+			state.remaining = limit
+		}
+		rl.states[key] = state
+	}
+	state.limit = limit
+
+	switch rl.algorithm {
+	case AlgorithmLeakyBucket:
+		return rl.allowLeaky(state, now)
+	default:
+		return rl.allowTokenBucket(state, now)
+	}
+}
+
+// allowTokenBucket refills the bucket to limit at the start of each window.
+func (rl *RateLimiter) allowTokenBucket(state *bucketState, now time.Time) (bool, time.Duration) {
+	if now.After(state.resetAt) || now.Equal(state.resetAt) {
+		state.remaining = state.limit
+		state.resetAt = now.Add(rl.window)
+	}
+
+	if state.remaining > 0 {
+		state.remaining--
+		return true, 0
+	}
+	return false, state.resetAt.Sub(now)
+}
+
+// allowLeaky decays remaining budget proportionally to elapsed time, then
+// admits the request if there's room under the limit.
+func (rl *RateLimiter) allowLeaky(state *bucketState, now time.Time) (bool, time.Duration) {
+	rate := rl.window / time.Duration(state.limit)
+	elapsed := now.Sub(state.updated)
+	decay := int(elapsed / rate)
+	if decay > 0 {
+		state.remaining -= decay
+		if state.remaining < 0 {
+			state.remaining = 0
+		}
+		state.updated = now
+	}
+
+	if state.remaining < state.limit {
+		state.remaining++
+		return true, 0
+	}
+	return false, rate
+}
+
+// Get returns the current remaining budget and reset time for key without
+// consuming any of it.
+func (rl *RateLimiter) Get(key string) (remaining int, limit int, resetAt time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	limit = rl.limitFor(key)
+	state, ok := rl.states[key]
+	if !ok {
+		return limit, limit, time.Now().Add(rl.window)
+	}
+
+	now := time.Now()
+	if rl.algorithm == AlgorithmLeakyBucket {
+		rate := rl.window / time.Duration(state.limit)
+		used := state.remaining - int(now.Sub(state.updated)/rate)
+		if used < 0 {
+			used = 0
+		}
+		return state.limit - used, state.limit, state.updated.Add(rate)
+	}
+	if !now.Before(state.resetAt) {
+		return state.limit, state.limit, now.Add(rl.window)
+	}
+	return state.remaining, state.limit, state.resetAt
+}
+
+// Peek reports the remaining budget for key without consuming it.
+func (rl *RateLimiter) Peek(key string) int {
+	remaining, _, _ := rl.Get(key)
+	return remaining
 }
 
 // Request represents an LLM API request with metadata
@@ -73,6 +365,30 @@ type Request struct {
 	CacheKey     string                 `json:"cache_key,omitempty"`
 	RequestID    string                 `json:"request_id"`
 	UserID       string                 `json:"user_id,omitempty"`
+
+	// Deadline, if non-zero, is the absolute time by which this request must
+	// complete. Timeout, if non-zero, is a relative deadline measured from
+	// when ProcessRequest starts. Whichever resolves earlier - these fields
+	// or an existing deadline on the caller's context - wins.
+	Deadline time.Time     `json:"deadline,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+}
+
+// withRequestDeadline derives a context bounded by the earlier of ctx's
+// existing deadline (if any) and req's Deadline/Timeout fields.
+func withRequestDeadline(ctx context.Context, req Request) (context.Context, context.CancelFunc) {
+	deadline := req.Deadline
+	if req.Timeout > 0 {
+		if byTimeout := time.Now().Add(req.Timeout); deadline.IsZero() || byTimeout.Before(deadline) {
+			deadline = byTimeout
+		}
+	}
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	// context.WithDeadline already takes the earlier of this deadline and
+	// any the parent ctx already carries.
+	return context.WithDeadline(ctx, deadline)
 }
 
 // ProxyResponse wraps the LLM response with additional metadata
@@ -86,12 +402,133 @@ type ProxyResponse struct {
 	Metadata     map[string]interface{} `json:"metadata"`
 }
 
+// StreamChunk is a single token-level piece of a streamed response.
+type StreamChunk struct {
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Usage    int    `json:"usage,omitempty"` // tokens used, only set on the final chunk
+	Model    string `json:"model"`
+}
+
+// replayChunkSize controls how finely a cached transcript is sliced when
+// replayed through ProcessRequestStream on a cache hit.
+const replayChunkSize = 40
+
+// ProcessRequestStream behaves like ProcessRequest but streams the response
+// token-by-token. On a cache hit the cached transcript is replayed in
+// replayChunkSize-sized pieces; otherwise the chunks come from
+// makeRequestStream and the assembled transcript is cached once the stream
+// completes successfully.
+func (p *LLMProxy) ProcessRequestStream(ctx context.Context, req Request) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk)
+	errs := make(chan error, 1)
+
+	ctx, cancel := withRequestDeadline(ctx, req)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+		defer cancel()
+
+		start := time.Now()
+
+		if err := p.checkRateLimits(req); err != nil {
+			errs <- err
+			return
+		}
+
+		if p.config.FilterFunction != nil {
+			req.Prompt = p.config.FilterFunction(req.Prompt)
+		}
+
+		if p.config.CacheEnabled {
+			cached, hit, err := p.cache.GetWithContext(ctx, req.CacheKey)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if hit {
+				p.recordMetrics(0, cached.TokensUsed, cached.Cost, true)
+				p.replayCached(ctx, cached, req.Model, chunks)
+				return
+			}
+		}
+
+		providerChunks, providerErrs := p.makeRequestStream(ctx, req)
+		var transcript strings.Builder
+		var totalTokens int
+
+		for {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case err, ok := <-providerErrs:
+				if ok && err != nil {
+					errs <- err
+					return
+				}
+			case chunk, ok := <-providerChunks:
+				if !ok {
+					p.recordMetrics(time.Since(start), int64(totalTokens), 0, false)
+					if p.config.CacheEnabled {
+						entry := p.newCacheEntry(&ProxyResponse{
+							Text:       transcript.String(),
+							TokensUsed: totalTokens,
+							Model:      req.Model,
+						})
+						p.cache.SetWithContext(ctx, req.CacheKey, entry)
+					}
+					return
+				}
+				transcript.WriteString(chunk.Text)
+				if chunk.Usage > 0 {
+					totalTokens = chunk.Usage
+				}
+				select {
+				case chunks <- chunk:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	return chunks, errs
+}
+
+// replayCached re-emits a cached transcript as a sequence of uniformly sized
+// StreamChunks so cache hits and cache misses present the same channel shape.
+func (p *LLMProxy) replayCached(ctx context.Context, cached CacheEntry, model string, chunks chan<- StreamChunk) {
+	text := string(cached.Response)
+	for i := 0; i < len(text); i += replayChunkSize {
+		end := i + replayChunkSize
+		if end > len(text) {
+			end = len(text)
+		}
+		chunk := StreamChunk{Text: text[i:end], Model: model}
+		if end == len(text) {
+			chunk.Done = true
+			chunk.Usage = cached.TokensUsed
+		}
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // ProcessRequest handles an LLM request through the proxy
 func (p *LLMProxy) ProcessRequest(ctx context.Context, req Request) (*ProxyResponse, error) {
 	start := time.Now()
 
+	ctx, cancel := withRequestDeadline(ctx, req)
+	defer cancel()
+
 	// Check rate limits
-	if err := p.checkRateLimits(); err != nil {
+	if err := p.checkRateLimits(req); err != nil {
 		return nil, err
 	}
 
@@ -102,21 +539,27 @@ func (p *LLMProxy) ProcessRequest(ctx context.Context, req Request) (*ProxyRespo
 
 	// Try cache first if enabled
 	if p.config.CacheEnabled {
-		if cached, hit := p.checkCache(req.CacheKey); hit {
+		if cached, hit, err := p.cache.GetWithContext(ctx, req.CacheKey); err != nil {
+			p.recordRecent(req, nil, err)
+			return nil, err
+		} else if hit {
 			p.recordMetrics(0, cached.TokensUsed, cached.Cost, true)
-			return &ProxyResponse{
+			resp := &ProxyResponse{
 				Text:      string(cached.Response),
 				CacheHit: true,
 				TokensUsed: cached.TokensUsed,
 				Cost:     cached.Cost,
 				Latency:  time.Since(start),
-			}, nil
+			}
+			p.recordRecent(req, resp, nil)
+			return resp, nil
 		}
 	}
 
 	// Process request with retries
 	response, err := p.makeRequestWithRetries(ctx, req)
 	if err != nil {
+		p.recordRecent(req, nil, err)
 		return nil, err
 	}
 
@@ -125,21 +568,57 @@ func (p *LLMProxy) ProcessRequest(ctx context.Context, req Request) (*ProxyRespo
 
 	// Cache response if enabled
 	if p.config.CacheEnabled {
-		p.cacheResponse(req.CacheKey, response)
+		if err := p.cache.SetWithContext(ctx, req.CacheKey, p.newCacheEntry(response)); err != nil {
+			p.recordRecent(req, response, err)
+			return response, nil
+		}
 	}
 
+	p.recordRecent(req, response, nil)
 	return response, nil
 }
 
-// checkRateLimits ensures we're within configured limits
-func (p *LLMProxy) checkRateLimits() error {
+// newCacheEntry builds the CacheEntry stored for a successful response,
+// expiring it after ProxyConfig.CacheTTL (or defaultCacheTTL if unset).
+func (p *LLMProxy) newCacheEntry(resp *ProxyResponse) CacheEntry {
+	ttl := p.config.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return CacheEntry{
+		Response:   json.RawMessage(resp.Text),
+		Expiration: time.Now().Add(ttl),
+		TokensUsed: resp.TokensUsed,
+		Cost:       resp.Cost,
+	}
+}
+
+// checkRateLimits ensures we're within the configured daily cost cap and the
+// per-minute request rate for req's key.
+func (p *LLMProxy) checkRateLimits(req Request) error {
 	p.metrics.mu.RLock()
-	defer p.metrics.mu.RUnlock()
+	overCost := p.metrics.EstimatedCost >= p.config.CostLimit
+	p.metrics.mu.RUnlock()
 
-	if p.metrics.EstimatedCost >= p.config.CostLimit {
+	if overCost {
 		return fmt.Errorf("daily cost limit exceeded: %.2f", p.config.CostLimit)
 	}
 
+	key := rateLimitKey(req)
+	allowed, retryAfter := p.limiter.Allow(key)
+
+	p.metrics.mu.Lock()
+	if allowed {
+		p.metrics.RateLimitAllowed++
+	} else {
+		p.metrics.RateLimitRejected++
+	}
+	p.metrics.mu.Unlock()
+
+	if !allowed {
+		return &RateLimitError{Key: key, RetryAfter: retryAfter}
+	}
+
 	return nil
 }
 
@@ -157,10 +636,119 @@ func (p *LLMProxy) recordMetrics(latency time.Duration, tokens int64, cost float
 	p.metrics.EstimatedCost += cost
 }
 
-// makeRequestWithRetries implements retry logic with exponential backoff
+// MetricsSnapshot is a point-in-time, lock-free copy of ProxyMetrics safe to
+// hand to callers outside the package (e.g. an introspection HTTP API).
+type MetricsSnapshot struct {
+	TotalRequests     int64
+	CacheHits         int64
+	Latency           time.Duration
+	TokensUsed        int64
+	EstimatedCost     float64
+	RateLimitAllowed  int64
+	RateLimitRejected int64
+}
+
+// CacheHitRatio returns CacheHits/TotalRequests, or 0 if there have been no
+// requests yet.
+func (m MetricsSnapshot) CacheHitRatio() float64 {
+	if m.TotalRequests == 0 {
+		return 0
+	}
+	return float64(m.CacheHits) / float64(m.TotalRequests)
+}
+
+// AverageLatency returns Latency/TotalRequests, or 0 if there have been no
+// requests yet.
+func (m MetricsSnapshot) AverageLatency() time.Duration {
+	if m.TotalRequests == 0 {
+		return 0
+	}
+	return m.Latency / time.Duration(m.TotalRequests)
+}
+
+// Metrics returns a snapshot of the proxy's current usage metrics.
+func (p *LLMProxy) Metrics() MetricsSnapshot {
+	p.metrics.mu.RLock()
+	defer p.metrics.mu.RUnlock()
+
+	return MetricsSnapshot{
+		TotalRequests:     p.metrics.TotalRequests,
+		CacheHits:         p.metrics.CacheHits,
+		Latency:           p.metrics.Latency,
+		TokensUsed:        p.metrics.TokensUsed,
+		EstimatedCost:     p.metrics.EstimatedCost,
+		RateLimitAllowed:  p.metrics.RateLimitAllowed,
+		RateLimitRejected: p.metrics.RateLimitRejected,
+	}
+}
+
+// CacheStats summarizes the current contents of a RequestCache.
+type CacheStats struct {
+	Entries int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// Stats walks the cache and summarizes its entry count, total size, and the
+// oldest/newest expiration among current entries.
+func (c *RequestCache) Stats() CacheStats {
+	var stats CacheStats
+
+	c.entries.Range(func(_, value interface{}) bool {
+		entry := value.(CacheEntry)
+		stats.Entries++
+		stats.Bytes += int64(len(entry.Response))
+		if stats.Oldest.IsZero() || entry.Expiration.Before(stats.Oldest) {
+			stats.Oldest = entry.Expiration
+		}
+		if entry.Expiration.After(stats.Newest) {
+			stats.Newest = entry.Expiration
+		}
+		return true
+	})
+
+	return stats
+}
+
+// Flush removes cache entries whose key starts with prefix, or every entry
+// when prefix is empty. It returns the number of entries removed.
+func (c *RequestCache) Flush(prefix string) int {
+	removed := 0
+
+	c.entries.Range(func(key, _ interface{}) bool {
+		k := key.(string)
+		if prefix == "" || strings.HasPrefix(k, prefix) {
+			c.entries.Delete(k)
+			removed++
+		}
+		return true
+	})
+
+	return removed
+}
+
+// Cache exposes the proxy's RequestCache for introspection and management.
+func (p *LLMProxy) Cache() *RequestCache {
+	return p.cache
+}
+
+// Limiter exposes the proxy's RateLimiter so callers can check remaining
+// budget via Get/Peek without consuming any of it.
+func (p *LLMProxy) Limiter() *RateLimiter {
+	return p.limiter
+}
+
+// makeRequestWithRetries implements retry logic with exponential backoff. A
+// single deadlineTimer is reset for each attempt's backoff wait, stopping the
+// previous attempt's timer before arming the next, and the wait also selects
+// on ctx so a tight Request.Deadline can abort it mid-retry.
 func (p *LLMProxy) makeRequestWithRetries(ctx context.Context, req Request) (*ProxyResponse, error) {
 	var lastErr error
 
+	dt := newDeadlineTimer()
+	defer dt.stop()
+
 	for attempt := 0; attempt < p.config.RetryConfig.MaxRetries; attempt++ {
 		response, err := p.makeRequest(ctx, req)
 		if err == nil {
@@ -169,11 +757,12 @@ func (p *LLMProxy) makeRequestWithRetries(ctx context.Context, req Request) (*Pr
 
 		lastErr = err
 		backoff := p.config.RetryConfig.BackoffBase * time.Duration(1<<attempt)
-		
+		dt.reset(backoff)
+
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-time.After(backoff):
+		case <-dt.writeCancel():
 			continue
 		}
 	}
@@ -181,6 +770,23 @@ func (p *LLMProxy) makeRequestWithRetries(ctx context.Context, req Request) (*Pr
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// makeRequestStream supplies the chunk stream for a cache miss in
+// ProcessRequestStream. It calls no provider and no streaming (SSE) endpoint:
+// it emits a single terminal chunk with the text "Sample response", the same
+// text OpenAIProvider.StreamText returns.
+func (p *LLMProxy) makeRequestStream(ctx context.Context, req Request) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+		chunks <- StreamChunk{Text: "Sample response", Done: true, Model: req.Model}
+	}()
+
+	return chunks, errs
+}
+
 // Example usage
 func Example() {
 	config := ProxyConfig{
@@ -190,8 +796,9 @@ func Example() {
 			MaxRetries:  3,
 			BackoffBase: time.Second,
 		},
-		RateLimit:  100,
-		CostLimit:  50.0,
+		RateLimit:          100,
+		RateLimitAlgorithm: AlgorithmTokenBucket,
+		CostLimit:          50.0,
 		FilterFunction: func(prompt string) string {
 			// Implement PII filtering logic
 			return prompt
@@ -0,0 +1,31 @@
+package llm
+
+import "testing"
+
+func TestRateLimiter_Allow_TokenBucket(t *testing.T) {
+	rl := newRateLimiter(ProxyConfig{RateLimit: 2, RateLimitAlgorithm: AlgorithmTokenBucket})
+
+	if allowed, _ := rl.Allow("user1"); !allowed {
+		t.Fatalf("first request should be admitted")
+	}
+	if allowed, _ := rl.Allow("user1"); !allowed {
+		t.Fatalf("second request should be admitted (within limit)")
+	}
+	if allowed, _ := rl.Allow("user1"); allowed {
+		t.Fatalf("third request should be rejected (limit exceeded)")
+	}
+}
+
+func TestRateLimiter_Allow_LeakyBucket(t *testing.T) {
+	rl := newRateLimiter(ProxyConfig{RateLimit: 2, RateLimitAlgorithm: AlgorithmLeakyBucket})
+
+	if allowed, _ := rl.Allow("user1"); !allowed {
+		t.Fatalf("first request from a brand-new key should be admitted")
+	}
+	if allowed, _ := rl.Allow("user1"); !allowed {
+		t.Fatalf("second request should be admitted (within limit)")
+	}
+	if allowed, _ := rl.Allow("user1"); allowed {
+		t.Fatalf("third request should be rejected (limit exceeded)")
+	}
+}
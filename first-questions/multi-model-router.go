@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,25 +37,165 @@ type ModelResponse struct {
 	Metadata   map[string]string `json:"metadata"`
 }
 
+// FallbackTarget names a (Provider, ModelName) pair a request can retry
+// against when its primary target fails or trips its circuit breaker.
+type FallbackTarget struct {
+	Provider  Provider
+	ModelName string
+}
+
 // ModelRouter handles routing requests to different LLM providers
 type ModelRouter struct {
-	providers map[Provider]ModelProvider
-	mutex     sync.RWMutex
-	fallbacks map[string][]string // maps model names to fallback options
+	providers   map[Provider]ModelProvider
+	mutex       sync.RWMutex
+	fallbacks   map[ModelKey][]FallbackTarget
+	credentials map[Provider]*credentialWatcher
+	breakers    map[ModelKey]*circuitBreaker
+	breakerCfg  CircuitBreakerConfig
 }
 
 // ModelProvider interface for different LLM providers
 type ModelProvider interface {
+	GenerateText(context.Context, ModelRequest) (ModelResponse, error)
+	StreamText(context.Context, ModelRequest) (<-chan StreamChunk, <-chan error)
+	IsAvailable() bool
+	UpdateCredential(Credential)
+}
+
+// nonStreamingProvider is ModelProvider without StreamText.
+type nonStreamingProvider interface {
 	GenerateText(context.Context, ModelRequest) (ModelResponse, error)
 	IsAvailable() bool
+	UpdateCredential(Credential)
+}
+
+// WrapNonStreaming adapts a provider that only implements GenerateText so it
+// satisfies ModelProvider, presenting the full response as a single terminal
+// StreamChunk.
+func WrapNonStreaming(provider nonStreamingProvider) ModelProvider {
+	return singleChunkAdapter{nonStreamingProvider: provider}
+}
+
+// singleChunkAdapter is the default StreamText implementation for providers
+// with no native streaming support.
+type singleChunkAdapter struct {
+	nonStreamingProvider
+}
+
+func (a singleChunkAdapter) StreamText(ctx context.Context, req ModelRequest) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		resp, err := a.GenerateText(ctx, req)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		chunks <- StreamChunk{Text: resp.Text, Done: true, Usage: resp.TokensUsed, Model: resp.ModelName}
+	}()
+
+	return chunks, errs
 }
 
 // NewModelRouter creates a new router instance
 func NewModelRouter() *ModelRouter {
 	return &ModelRouter{
-		providers: make(map[Provider]ModelProvider),
-		fallbacks: make(map[string][]string),
+		providers:   make(map[Provider]ModelProvider),
+		fallbacks:   make(map[ModelKey][]FallbackTarget),
+		credentials: make(map[Provider]*credentialWatcher),
+		breakers:    make(map[ModelKey]*circuitBreaker),
+		breakerCfg:  DefaultCircuitBreakerConfig,
+	}
+}
+
+// breakerFor returns the circuit breaker for key, creating one on first use.
+func (r *ModelRouter) breakerFor(key ModelKey) *circuitBreaker {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	b, ok := r.breakers[key]
+	if !ok {
+		b = newCircuitBreaker(r.breakerCfg)
+		r.breakers[key] = b
+	}
+	return b
+}
+
+// HealthSnapshot returns the current circuit breaker state for every model
+// that has seen at least one request.
+func (r *ModelRouter) HealthSnapshot() map[ModelKey]ProviderHealth {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	snapshot := make(map[ModelKey]ProviderHealth, len(r.breakers))
+	for key, b := range r.breakers {
+		snapshot[key] = b.snapshot()
+	}
+	return snapshot
+}
+
+// ProviderInfo summarizes one registered provider for introspection.
+type ProviderInfo struct {
+	Provider  Provider
+	Available bool
+}
+
+// Providers lists every registered provider and its current availability.
+func (r *ModelRouter) Providers() []ProviderInfo {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	infos := make([]ProviderInfo, 0, len(r.providers))
+	for provider, client := range r.providers {
+		infos = append(infos, ProviderInfo{Provider: provider, Available: client.IsAvailable()})
+	}
+	return infos
+}
+
+// ResetCircuit forces the breaker for key back to Closed. It reports false if
+// no breaker has been created for key yet (i.e. it has never seen traffic).
+func (r *ModelRouter) ResetCircuit(key ModelKey) bool {
+	r.mutex.RLock()
+	b, exists := r.breakers[key]
+	r.mutex.RUnlock()
+
+	if !exists {
+		return false
 	}
+	b.Reset()
+	return true
+}
+
+// WatchCredentials fetches an initial credential for provider from source,
+// applies it immediately, and-if the credential is renewable-starts a
+// background goroutine that refreshes it at TTL*2/3 for as long as ctx is
+// alive. Fetch failures are retried with backoff while the previous
+// credential keeps serving requests.
+func (r *ModelRouter) WatchCredentials(ctx context.Context, provider Provider, source CredentialSource) error {
+	r.mutex.RLock()
+	client, exists := r.providers[provider]
+	r.mutex.RUnlock()
+	if !exists {
+		return errors.New("provider not found")
+	}
+
+	watcher, err := watchCredential(ctx, source, client.UpdateCredential)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	if old, ok := r.credentials[provider]; ok {
+		old.Stop()
+	}
+	r.credentials[provider] = watcher
+	r.mutex.Unlock()
+
+	return nil
 }
 
 // RegisterProvider adds a new provider to the router
@@ -64,60 +205,85 @@ func (r *ModelRouter) RegisterProvider(provider Provider, client ModelProvider)
 	r.providers[provider] = client
 }
 
-// SetFallbacks configures fallback models for a given model
-func (r *ModelRouter) SetFallbacks(modelName string, fallbackModels []string) {
+// SetFallbacks configures the ordered (Provider, ModelName) pairs to try when
+// provider/modelName fails or its circuit breaker is open. Each fallback
+// names its own provider so, for example, a failed OpenAI model can fall
+// back to a model registered under Anthropic.
+func (r *ModelRouter) SetFallbacks(provider Provider, modelName string, fallbacks []FallbackTarget) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	r.fallbacks[modelName] = fallbackModels
+	r.fallbacks[ModelKey{Provider: provider, ModelName: modelName}] = fallbacks
 }
 
 // RouteRequest routes the request to appropriate provider with fallback support
 func (r *ModelRouter) RouteRequest(ctx context.Context, req ModelRequest) (ModelResponse, error) {
-	r.mutex.RLock()
-	provider, exists := r.providers[req.Provider]
-	r.mutex.RUnlock()
+	key := ModelKey{Provider: req.Provider, ModelName: req.ModelName}
+	breaker := r.breakerFor(key)
 
-	if !exists {
-		return ModelResponse{}, errors.New("provider not found")
-	}
+	if breaker.Allow() {
+		r.mutex.RLock()
+		provider, exists := r.providers[req.Provider]
+		r.mutex.RUnlock()
 
-	// Try primary model
-	if provider.IsAvailable() {
-		resp, err := provider.GenerateText(ctx, req)
-		if err == nil {
-			return resp, nil
+		if !exists {
+			breaker.ReleaseProbe()
+			return ModelResponse{}, errors.New("provider not found")
+		}
+
+		if provider.IsAvailable() {
+			start := time.Now()
+			resp, err := provider.GenerateText(ctx, req)
+			breaker.RecordOutcome(err == nil, time.Since(start), classifyError(err), err)
+			if err == nil {
+				return resp, nil
+			}
+		} else {
+			breaker.ReleaseProbe()
 		}
 	}
 
-	// Try fallbacks
+	// Breaker is Open (or the primary call failed): try fallbacks.
 	return r.tryFallbacks(ctx, req)
 }
 
-// tryFallbacks attempts to use configured fallback models
+// tryFallbacks attempts each configured (Provider, ModelName) fallback in
+// order, honoring each target's own circuit breaker.
 func (r *ModelRouter) tryFallbacks(ctx context.Context, req ModelRequest) (ModelResponse, error) {
 	r.mutex.RLock()
-	fallbacks, exists := r.fallbacks[req.ModelName]
+	fallbacks, exists := r.fallbacks[ModelKey{Provider: req.Provider, ModelName: req.ModelName}]
 	r.mutex.RUnlock()
 
 	if !exists {
 		return ModelResponse{}, errors.New("no fallbacks configured")
 	}
 
-	for _, fallbackModel := range fallbacks {
-		// Create new request with fallback model
-		fallbackReq := req
-		fallbackReq.ModelName = fallbackModel
-
-		provider, exists := r.providers[req.Provider]
+	for _, target := range fallbacks {
+		r.mutex.RLock()
+		provider, exists := r.providers[target.Provider]
+		r.mutex.RUnlock()
 		if !exists {
 			continue
 		}
 
-		if provider.IsAvailable() {
-			resp, err := provider.GenerateText(ctx, fallbackReq)
-			if err == nil {
-				return resp, nil
-			}
+		breaker := r.breakerFor(ModelKey{Provider: target.Provider, ModelName: target.ModelName})
+		if !breaker.Allow() {
+			continue
+		}
+
+		if !provider.IsAvailable() {
+			breaker.ReleaseProbe()
+			continue
+		}
+
+		fallbackReq := req
+		fallbackReq.Provider = target.Provider
+		fallbackReq.ModelName = target.ModelName
+
+		start := time.Now()
+		resp, err := provider.GenerateText(ctx, fallbackReq)
+		breaker.RecordOutcome(err == nil, time.Since(start), classifyError(err), err)
+		if err == nil {
+			return resp, nil
 		}
 	}
 
@@ -126,24 +292,30 @@ func (r *ModelRouter) tryFallbacks(ctx context.Context, req ModelRequest) (Model
 
 // Example implementation of an OpenAI provider
 type OpenAIProvider struct {
-	apiKey     string
+	credential atomic.Pointer[Credential]
 	availabile bool
 	models     map[string]bool
 }
 
 func NewOpenAIProvider(apiKey string) *OpenAIProvider {
-	return &OpenAIProvider{
-		apiKey: apiKey,
+	p := &OpenAIProvider{
 		availabile: true,
 		models: map[string]bool{
 			"gpt-4": true,
 			"gpt-3.5-turbo": true,
 		},
 	}
+	p.credential.Store(&Credential{Value: apiKey})
+	return p
+}
+
+// UpdateCredential atomically swaps the API key used for subsequent requests.
+func (p *OpenAIProvider) UpdateCredential(cred Credential) {
+	p.credential.Store(&cred)
 }
 
 func (p *OpenAIProvider) GenerateText(ctx context.Context, req ModelRequest) (ModelResponse, error) {
-	// Implementation of OpenAI API call would go here
+	// Implementation of OpenAI API call would go here, using p.credential.Load().Value as the key
 	return ModelResponse{
 		Text:      "Sample response",
 		Provider:  OpenAI,
@@ -151,8 +323,37 @@ func (p *OpenAIProvider) GenerateText(ctx context.Context, req ModelRequest) (Mo
 	}, nil
 }
 
+func (p *OpenAIProvider) StreamText(ctx context.Context, req ModelRequest) (<-chan StreamChunk, <-chan error) {
+	// Implementation of the OpenAI streaming (stream: true) API call would go
+	// here; until then, delegate to GenerateText so the stream and non-stream
+	// paths return the same text instead of silently diverging.
+	chunks := make(chan StreamChunk, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		resp, err := p.GenerateText(ctx, req)
+		if err != nil {
+			errs <- err
+			return
+		}
+		chunks <- StreamChunk{Text: resp.Text, Done: true, Usage: resp.TokensUsed, Model: resp.ModelName}
+	}()
+
+	return chunks, errs
+}
+
 func (p *OpenAIProvider) IsAvailable() bool {
-	return p.availabile
+	if !p.availabile {
+		return false
+	}
+	cred := p.credential.Load()
+	if cred.TTL > 0 && time.Now().After(cred.ExpiresAt()) {
+		return false
+	}
+	return true
 }
 
 // Usage example
@@ -161,9 +362,10 @@ func Example() {
 
 	// Register providers
 	router.RegisterProvider(OpenAI, NewOpenAIProvider("api-key"))
+	router.RegisterProvider(Anthropic, newAnthropicClient("api-key"))
 
 	// Configure fallbacks
-	router.SetFallbacks("gpt-4", []string{"gpt-3.5-turbo"})
+	router.SetFallbacks(OpenAI, "gpt-4", []FallbackTarget{{Provider: Anthropic, ModelName: "claude-3-5-sonnet-20240620"}, {Provider: OpenAI, ModelName: "gpt-3.5-turbo"}})
 
 	// Make a request
 	req := ModelRequest{